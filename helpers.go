@@ -6,30 +6,47 @@ import (
 	"strings"
 	"time"
 
+	"github.com/libdns/leaseweb/internal/client"
 	"github.com/libdns/libdns"
 )
 
 // @see https://developer.leaseweb.com/api-docs/domains_v2.html#tag/DNS/operation/domains-resourcerecordsets-post
 var supportedTTLs = []int{60, 300, 1800, 3600, 14400, 28800, 43200, 86400}
 
-func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, error) {
+func fromLibdns(zone string, records []libdns.Record) ([]client.RecordSet, error) {
+	var domainName = strings.TrimSuffix(zone, ".")
+
 	var recordsInfo = []struct {
-		libdnsRecord libdns.Record
-		consumed     bool
+		libdnsRecord   libdns.Record
+		normalizedName string
+		consumed       bool
 	}{}
 	for _, record := range records {
+		// Cleanup record name and ensure it ends with domain.ext[dot] even if dns_challenge_override_domain is set
+		// trimming both zone & domainName is probably overzealous, but better be safe then sorry
+		// Example:
+		//   zone: example.com.
+		//   domainName: example.com
+		//   dnsRecord.Name 1: _acme-challenge.example.com
+		//   dnsRecord.Name 2: _acme-challenge.example.com.
+		//   dnsRecord.Name 3: _acme-challenge.
+		//   all after cleanup -> _acme-challenge.example.com.
+		relativeName := strings.TrimSuffix(strings.TrimSuffix(strings.TrimSuffix(record.Name, zone), "."), domainName)
+		normalizedName := fmt.Sprintf("%s.%s", strings.TrimSuffix(relativeName, "."), zone)
+
 		recordsInfo = append(recordsInfo, struct {
-			libdnsRecord libdns.Record
-			consumed     bool
+			libdnsRecord   libdns.Record
+			normalizedName string
+			consumed       bool
 		}{
-			libdnsRecord: record,
-			consumed:     false,
+			libdnsRecord:   record,
+			normalizedName: normalizedName,
+			consumed:       false,
 		})
 	}
 
 	var errors []string
-	var recordSets []leasewebRecordSet
-	var domainName = strings.TrimSuffix(zone, ".")
+	var recordSets []client.RecordSet
 
 	for currentIdx := 0; currentIdx < len(recordsInfo); currentIdx++ {
 		var currentRecordInfo = &recordsInfo[currentIdx]
@@ -39,16 +56,6 @@ func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, erro
 		}
 		currentRecordInfo.consumed = true
 
-		// Cleanup record name and ensure it ends with domain.ext[dot] even if dns_challenge_override_domain is set
-		// trimming both zone & domainName is probably overzealous, but better be safe then sorry
-		// Example:
-		//   zone: example.com.
-		//   domainName: example.com
-		//   dnsRecord.Name 1: _acme-challenge.example.com
-		//   dnsRecord.Name 2: _acme-challenge.example.com.
-		//   dnsRecord.Name 3: _acme-challenge.
-		//   all after cleanup -> _acme-challenge.example.com.
-		var recordName = fmt.Sprintf("%s.%s", strings.TrimSuffix(strings.TrimSuffix(currentRecordInfo.libdnsRecord.Name, zone), domainName), zone)
 		var recordTTL = int(currentRecordInfo.libdnsRecord.TTL.Seconds())
 		if !slices.Contains(supportedTTLs, recordTTL) {
 			// Use the first listed TTL if the user did not provide a TTL or provided a unsupported value
@@ -56,8 +63,8 @@ func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, erro
 			recordTTL = supportedTTLs[0]
 		}
 
-		var newRecordSet = leasewebRecordSet{
-			Name:    recordName,
+		var newRecordSet = client.RecordSet{
+			Name:    currentRecordInfo.normalizedName,
 			Type:    currentRecordInfo.libdnsRecord.Type,
 			TTL:     recordTTL,
 			Content: []string{currentRecordInfo.libdnsRecord.Value},
@@ -65,11 +72,11 @@ func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, erro
 
 		for otherIdx := 0; otherIdx < len(recordsInfo); otherIdx++ {
 			var otherRecordInfo = &recordsInfo[otherIdx]
-			if otherIdx == currentIdx {
+			if otherIdx == currentIdx || otherRecordInfo.consumed {
 				continue
 			}
 
-			if otherRecordInfo.libdnsRecord.Name == newRecordSet.Name && otherRecordInfo.libdnsRecord.Type == currentRecordInfo.libdnsRecord.Type {
+			if otherRecordInfo.normalizedName == newRecordSet.Name && otherRecordInfo.libdnsRecord.Type == currentRecordInfo.libdnsRecord.Type {
 				otherRecordInfo.consumed = true
 
 				var otherTTL = int(otherRecordInfo.libdnsRecord.TTL.Seconds())
@@ -91,7 +98,7 @@ func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, erro
 	return recordSets, nil
 }
 
-func fromLeaseweb(recordSets leasewebRecordSets) []libdns.Record {
+func fromLeaseweb(recordSets client.RecordSets) []libdns.Record {
 	var records []libdns.Record
 	for _, resourceRecordSet := range recordSets.ResourceRecordSets {
 		for _, content := range resourceRecordSet.Content {
@@ -0,0 +1,118 @@
+package leaseweb
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libdns/leaseweb/internal/client"
+	"github.com/libdns/libdns"
+)
+
+func TestFromLibdns(t *testing.T) {
+	tests := []struct {
+		name    string
+		zone    string
+		records []libdns.Record
+		want    []client.RecordSet
+		wantErr bool
+	}{
+		{
+			name: "single record, no trailing dot",
+			zone: "example.com.",
+			records: []libdns.Record{
+				{Name: "www", Type: "A", Value: "203.0.113.10", TTL: 3600 * time.Second},
+			},
+			want: []client.RecordSet{
+				{Name: "www.example.com.", Type: "A", Content: []string{"203.0.113.10"}, TTL: 3600},
+			},
+		},
+		{
+			name: "zone without a trailing dot",
+			zone: "example.com",
+			records: []libdns.Record{
+				{Name: "www", Type: "A", Value: "203.0.113.10", TTL: 3600 * time.Second},
+			},
+			want: []client.RecordSet{
+				{Name: "www.example.com", Type: "A", Content: []string{"203.0.113.10"}, TTL: 3600},
+			},
+		},
+		{
+			// Multiple libdns.Records sharing a Name/Type merge into one
+			// RecordSet with both values in Content, since Leaseweb has a
+			// single resourceRecordSet per name+type. This is what lets a
+			// combined wildcard+apex cert's DNS-01 challenge publish two
+			// _acme-challenge TXT values under one record.
+			name: "same-name, same-type records are merged",
+			zone: "example.com.",
+			records: []libdns.Record{
+				{Name: "_acme-challenge", Type: "TXT", Value: "token-one", TTL: 300 * time.Second},
+				{Name: "_acme-challenge", Type: "TXT", Value: "token-two", TTL: 300 * time.Second},
+			},
+			want: []client.RecordSet{
+				{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{"token-one", "token-two"}, TTL: 300},
+			},
+		},
+		{
+			name: "unsupported TTL is coerced to the lowest supported value",
+			zone: "example.com.",
+			records: []libdns.Record{
+				{Name: "www", Type: "A", Value: "203.0.113.10", TTL: 90 * time.Second},
+			},
+			want: []client.RecordSet{
+				{Name: "www.example.com.", Type: "A", Content: []string{"203.0.113.10"}, TTL: supportedTTLs[0]},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := fromLibdns(tt.zone, tt.records)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("fromLibdns() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			sortRecordSets(got)
+			sortRecordSets(tt.want)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("fromLibdns() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFromLeaseweb(t *testing.T) {
+	recordSets := client.RecordSets{
+		ResourceRecordSets: []client.RecordSet{
+			{Name: "www.example.com.", Type: "A", Content: []string{"203.0.113.10"}, TTL: 3600},
+			{Name: "_acme-challenge.example.com.", Type: "TXT", Content: []string{"token-one", "token-two"}, TTL: 300},
+		},
+	}
+
+	want := []libdns.Record{
+		{Name: "www.example.com.", Type: "A", Value: "203.0.113.10", TTL: 3600 * time.Second},
+		{Name: "_acme-challenge.example.com.", Type: "TXT", Value: "token-one", TTL: 300 * time.Second},
+		{Name: "_acme-challenge.example.com.", Type: "TXT", Value: "token-two", TTL: 300 * time.Second},
+	}
+
+	got := fromLeaseweb(recordSets)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fromLeaseweb() = %+v, want %+v", got, want)
+	}
+}
+
+func sortRecordSets(rs []client.RecordSet) {
+	sort.Slice(rs, func(i, j int) bool {
+		if rs[i].Name != rs[j].Name {
+			return rs[i].Name < rs[j].Name
+		}
+		if rs[i].Type != rs[j].Type {
+			return rs[i].Type < rs[j].Type
+		}
+		return strings.Join(rs[i].Content, ",") < strings.Join(rs[j].Content, ",")
+	})
+}
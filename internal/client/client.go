@@ -0,0 +1,204 @@
+// Package client is a minimal HTTP client for the Leaseweb Domains v2 API,
+// used internally by the leaseweb libdns provider.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"time"
+)
+
+// APIKeyHeader is the HTTP header Leaseweb expects the API key in.
+const APIKeyHeader = "X-LSW-Auth"
+
+const (
+	defaultBaseURL    = "https://api.leaseweb.com/hosting/v2"
+	defaultMaxRetries = 3
+	userAgent         = "libdns-leaseweb"
+
+	minBackoff = 500 * time.Millisecond
+	maxBackoff = 30 * time.Second
+)
+
+// Client talks to the Leaseweb Domains v2 API.
+//
+// The zero value is not ready to use; construct one with New. Fields left
+// zero after construction fall back to sane defaults, which lets tests
+// override just HTTPClient and BaseURL to point at an httptest.Server.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	APIKey     string
+	MaxRetries int
+}
+
+// New creates a Client for the given API key, ready to use against the real
+// Leaseweb API.
+func New(apiKey string) *Client {
+	return &Client{APIKey: apiKey}
+}
+
+func (c *Client) httpClient() *http.Client {
+	if c.HTTPClient != nil {
+		return c.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+func (c *Client) baseURL() string {
+	if c.BaseURL != "" {
+		return c.BaseURL
+	}
+	return defaultBaseURL
+}
+
+// maxRetries returns how many retries a request gets. Zero means "unset",
+// which falls back to defaultMaxRetries; a negative MaxRetries disables
+// retries entirely, since there'd otherwise be no way to express that.
+func (c *Client) maxRetries() int {
+	switch {
+	case c.MaxRetries < 0:
+		return 0
+	case c.MaxRetries == 0:
+		return defaultMaxRetries
+	default:
+		return c.MaxRetries
+	}
+}
+
+// GetRecordSets lists every resource record set for domainName.
+func (c *Client) GetRecordSets(ctx context.Context, domainName string) (RecordSets, error) {
+	var out RecordSets
+	path := fmt.Sprintf("/domains/%s/resourceRecordSets", domainName)
+	err := c.do(ctx, http.MethodGet, "list", path, nil, &out)
+	return out, err
+}
+
+// CreateRecordSet creates a new resource record set and returns the
+// server's representation of it.
+func (c *Client) CreateRecordSet(ctx context.Context, domainName string, recordSet RecordSet) (RecordSet, error) {
+	out := recordSet
+	path := fmt.Sprintf("/domains/%s/resourceRecordSets", domainName)
+	err := c.do(ctx, http.MethodPost, "create", path, recordSet, &out)
+	return out, err
+}
+
+// UpdateRecordSet overwrites the content and TTL of an existing resource
+// record set, identified by its name and type.
+func (c *Client) UpdateRecordSet(ctx context.Context, domainName string, recordSet RecordSet) error {
+	path := fmt.Sprintf("/domains/%s/resourceRecordSets/%s/%s", domainName, recordSet.Name, recordSet.Type)
+	body := updateRecordSetRequest{Content: recordSet.Content, TTL: recordSet.TTL}
+	return c.do(ctx, http.MethodPut, "update", path, body, nil)
+}
+
+// DeleteRecordSet deletes the resource record set identified by name and
+// recordType. Leaseweb returns success even if the record set doesn't
+// exist.
+func (c *Client) DeleteRecordSet(ctx context.Context, domainName, name, recordType string) error {
+	path := fmt.Sprintf("/domains/%s/resourceRecordSets/%s/%s", domainName, name, recordType)
+	return c.do(ctx, http.MethodDelete, "delete", path, nil, nil)
+}
+
+// ListDomains returns one page of the domains manageable by the API key,
+// starting at offset and containing at most limit entries. Callers paginate
+// by inspecting the returned Domains.Metadata.TotalCount.
+func (c *Client) ListDomains(ctx context.Context, limit, offset int) (Domains, error) {
+	var out Domains
+	path := fmt.Sprintf("/domains?limit=%d&offset=%d", limit, offset)
+	err := c.do(ctx, http.MethodGet, "list-domains", path, nil, &out)
+	return out, err
+}
+
+// GetDomain returns the details, including authoritative nameservers, for a
+// single domain.
+func (c *Client) GetDomain(ctx context.Context, domainName string) (DomainDetails, error) {
+	var out DomainDetails
+	path := fmt.Sprintf("/domains/%s", domainName)
+	err := c.do(ctx, http.MethodGet, "get-domain", path, nil, &out)
+	return out, err
+}
+
+// do performs an HTTP request against the Leaseweb API, retrying 429 and 5xx
+// responses with exponential backoff (honoring Retry-After when present)
+// until maxRetries is exhausted or ctx is done.
+func (c *Client) do(ctx context.Context, method, op, path string, reqBody, out any) error {
+	var rawBody []byte
+	if reqBody != nil {
+		b, err := json.Marshal(reqBody)
+		if err != nil {
+			return fmt.Errorf("leaseweb: %s: encoding request: %w", op, err)
+		}
+		rawBody = b
+	}
+
+	var apiErr *APIError
+	for attempt := 0; attempt <= c.maxRetries(); attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return fmt.Errorf("leaseweb: %s: %w", op, ctx.Err())
+			case <-time.After(backoffDelay(attempt, apiErr.RetryAfter)):
+			}
+		}
+
+		var bodyReader io.Reader
+		if rawBody != nil {
+			bodyReader = bytes.NewReader(rawBody)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, c.baseURL()+path, bodyReader)
+		if err != nil {
+			return fmt.Errorf("leaseweb: %s: building request: %w", op, err)
+		}
+		req.Header.Set("User-Agent", userAgent)
+		req.Header.Set(APIKeyHeader, c.APIKey)
+		if rawBody != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		res, err := c.httpClient().Do(req)
+		if err != nil {
+			return fmt.Errorf("leaseweb: %s: %w", op, err)
+		}
+
+		data, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			return fmt.Errorf("leaseweb: %s: reading response: %w", op, err)
+		}
+
+		if res.StatusCode >= 200 && res.StatusCode < 300 {
+			if out != nil && len(data) > 0 {
+				if err := json.Unmarshal(data, out); err != nil {
+					return fmt.Errorf("leaseweb: %s: decoding response: %w", op, err)
+				}
+			}
+			return nil
+		}
+
+		apiErr = newAPIError(op, res, data)
+		if !isRetryable(res.StatusCode) || attempt == c.maxRetries() {
+			return apiErr
+		}
+	}
+
+	return apiErr
+}
+
+// backoffDelay returns how long to wait before the next retry attempt,
+// honoring a server-provided Retry-After duration when present.
+func backoffDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return retryAfter
+	}
+	delay := time.Duration(math.Pow(2, float64(attempt-1))) * minBackoff
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+	return delay
+}
@@ -0,0 +1,238 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+)
+
+func mustReadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Client{
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+		APIKey:     "test-api-key",
+		MaxRetries: 1,
+	}
+}
+
+func TestClient_GetRecordSets(t *testing.T) {
+	fixture := mustReadFixture(t, "list_record_sets.json")
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/domains/example.com/resourceRecordSets"; got != want {
+			t.Errorf("path = %q, want %q", got, want)
+		}
+		if got := r.Header.Get(APIKeyHeader); got != "test-api-key" {
+			t.Errorf("%s header = %q, want %q", APIKeyHeader, got, "test-api-key")
+		}
+		w.Write(fixture)
+	})
+
+	recordSets, err := c.GetRecordSets(context.Background(), "example.com")
+	if err != nil {
+		t.Fatalf("GetRecordSets() error = %v", err)
+	}
+	if len(recordSets.ResourceRecordSets) != 2 {
+		t.Fatalf("got %d record sets, want 2", len(recordSets.ResourceRecordSets))
+	}
+	if got, want := recordSets.ResourceRecordSets[1].Content, []string{"token-one", "token-two"}; len(got) != len(want) {
+		t.Errorf("content = %v, want %v", got, want)
+	}
+}
+
+func TestClient_CreateRecordSet(t *testing.T) {
+	fixture := mustReadFixture(t, "create_record_set.json")
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("method = %q, want POST", r.Method)
+		}
+		w.WriteHeader(http.StatusCreated)
+		w.Write(fixture)
+	})
+
+	recordSet, err := c.CreateRecordSet(context.Background(), "example.com", RecordSet{
+		Name:    "_acme-challenge.example.com.",
+		Type:    "TXT",
+		Content: []string{"token-one"},
+		TTL:     300,
+	})
+	if err != nil {
+		t.Fatalf("CreateRecordSet() error = %v", err)
+	}
+	if recordSet.TTL != 300 {
+		t.Errorf("TTL = %d, want 300", recordSet.TTL)
+	}
+}
+
+func TestClient_UpdateRecordSet(t *testing.T) {
+	var gotPath string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		if r.Method != http.MethodPut {
+			t.Errorf("method = %q, want PUT", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	err := c.UpdateRecordSet(context.Background(), "example.com", RecordSet{
+		Name:    "www.example.com.",
+		Type:    "A",
+		Content: []string{"203.0.113.20"},
+		TTL:     3600,
+	})
+	if err != nil {
+		t.Fatalf("UpdateRecordSet() error = %v", err)
+	}
+	if want := "/domains/example.com/resourceRecordSets/www.example.com./A"; gotPath != want {
+		t.Errorf("path = %q, want %q", gotPath, want)
+	}
+}
+
+func TestClient_DeleteRecordSet(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("method = %q, want DELETE", r.Method)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if err := c.DeleteRecordSet(context.Background(), "example.com", "www.example.com.", "A"); err != nil {
+		t.Fatalf("DeleteRecordSet() error = %v", err)
+	}
+}
+
+func TestClient_ListDomains(t *testing.T) {
+	page1 := mustReadFixture(t, "list_domains_page1.json")
+	page2 := mustReadFixture(t, "list_domains_page2.json")
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Path, "/domains"; got != want {
+			t.Errorf("path = %q, want %q", got, want)
+		}
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write(page1)
+		case "2":
+			w.Write(page2)
+		default:
+			t.Errorf("unexpected offset query: %s", r.URL.RawQuery)
+		}
+	})
+
+	got, err := c.ListDomains(context.Background(), 2, 0)
+	if err != nil {
+		t.Fatalf("ListDomains() error = %v", err)
+	}
+	if len(got.Domains) != 2 || got.Metadata.TotalCount != 3 {
+		t.Fatalf("page 1 = %+v", got)
+	}
+
+	got, err = c.ListDomains(context.Background(), 2, 2)
+	if err != nil {
+		t.Fatalf("ListDomains() error = %v", err)
+	}
+	if len(got.Domains) != 1 || got.Domains[0].DomainName != "example.org" {
+		t.Fatalf("page 2 = %+v", got)
+	}
+}
+
+func TestClient_ErrorResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+	}{
+		{"unauthorized", http.StatusUnauthorized, string(mustReadFixture(t, "error_401.json"))},
+		{"malformed json", http.StatusBadRequest, "not json"},
+		{"server error", http.StatusInternalServerError, `{"userMessage":"boom"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int
+			c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+				attempts++
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.body))
+			})
+			c.MaxRetries = -1 // disable retries so a retryable status still fails fast
+
+			_, err := c.GetRecordSets(context.Background(), "example.com")
+			if err == nil {
+				t.Fatal("expected an error, got nil")
+			}
+			if attempts != 1 {
+				t.Errorf("attempts = %d, want 1", attempts)
+			}
+
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("error = %v, want *APIError", err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("StatusCode = %d, want %d", apiErr.StatusCode, tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestClient_RetriesOnRateLimit(t *testing.T) {
+	var attempts int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"userMessage":"rate limited"}`))
+			return
+		}
+		w.Write(mustReadFixture(t, "list_record_sets.json"))
+	})
+	c.MaxRetries = 2
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	if _, err := c.GetRecordSets(ctx, "example.com"); err != nil {
+		t.Fatalf("GetRecordSets() error = %v", err)
+	}
+	if attempts != 2 {
+		t.Errorf("attempts = %d, want 2", attempts)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+	}{
+		{"", 0},
+		{"5", 5 * time.Second},
+		{"not-a-number-or-date", 0},
+	}
+
+	for _, tt := range tests {
+		got := parseRetryAfter(tt.header)
+		if got != tt.want {
+			t.Errorf("parseRetryAfter(%q) = %v, want %v", tt.header, got, tt.want)
+		}
+	}
+}
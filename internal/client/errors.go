@@ -0,0 +1,71 @@
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// errorResponse is the error body returned by the Leaseweb API.
+type errorResponse struct {
+	ErrorMessage  string `json:"errorMessage"`
+	UserMessage   string `json:"userMessage"`
+	CorrelationID string `json:"correlationId"`
+	// ErrorDetails is a free-form JSON object, not a string, so it is kept as
+	// raw JSON; we only ever fold it into Error()'s output as opaque text.
+	ErrorDetails json.RawMessage `json:"errorDetails"`
+}
+
+// APIError is returned whenever the Leaseweb API responds with a non-2xx
+// status code. Callers can use errors.As to recover the status code and the
+// API's own error message.
+type APIError struct {
+	Op         string
+	StatusCode int
+	RetryAfter time.Duration
+	errorResponse
+}
+
+func (e *APIError) Error() string {
+	// UserMessage is meant to be human-readable; ErrorMessage is a fallback
+	// for responses that only fill in the more technical field.
+	msg := e.UserMessage
+	if msg == "" {
+		msg = e.ErrorMessage
+	}
+	if msg == "" {
+		return fmt.Sprintf("leaseweb: %s: unexpected status code %d", e.Op, e.StatusCode)
+	}
+	if len(e.ErrorDetails) > 0 && string(e.ErrorDetails) != "null" {
+		return fmt.Sprintf("leaseweb: %s: %d %s: %s (correlationId: %s)", e.Op, e.StatusCode, msg, string(e.ErrorDetails), e.CorrelationID)
+	}
+	return fmt.Sprintf("leaseweb: %s: %d %s (correlationId: %s)", e.Op, e.StatusCode, msg, e.CorrelationID)
+}
+
+func newAPIError(op string, res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{Op: op, StatusCode: res.StatusCode}
+	// Best-effort: the body isn't always JSON (e.g. an upstream proxy error),
+	// in which case the error simply falls back to the status code.
+	_ = json.Unmarshal(body, &apiErr.errorResponse)
+	apiErr.RetryAfter = parseRetryAfter(res.Header.Get("Retry-After"))
+	return apiErr
+}
+
+func parseRetryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if when, err := http.ParseTime(header); err == nil {
+		return time.Until(when)
+	}
+	return 0
+}
+
+func isRetryable(statusCode int) bool {
+	return statusCode == http.StatusTooManyRequests || statusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,60 @@
+package client
+
+// RecordSet is a single Leaseweb DNS resource record set, as returned by and
+// sent to the resourceRecordSets endpoints.
+//
+// @see https://developer.leaseweb.com/api-docs/domains_v2.html#tag/DNS
+type RecordSet struct {
+	Name    string   `json:"name"`
+	Type    string   `json:"type"`
+	Content []string `json:"content"`
+	TTL     int      `json:"ttl"`
+}
+
+// RecordSets is the response envelope returned by the list
+// resourceRecordSets endpoint.
+type RecordSets struct {
+	ResourceRecordSets []RecordSet `json:"resourceRecordSets"`
+}
+
+// updateRecordSetRequest is the body accepted by the PUT
+// resourceRecordSets/{name}/{type} endpoint, which unlike the list/create
+// responses does not accept or return a name/type.
+//
+// @see https://developer.leaseweb.com/api-docs/domains_v2.html#operation/put/domains/{domainName}/resourceRecordSets/{name}/{type}
+type updateRecordSetRequest struct {
+	Content []string `json:"content"`
+	TTL     int      `json:"ttl"`
+}
+
+// Domain is a single domain entry as returned by the paginated domains
+// listing.
+//
+// @see https://developer.leaseweb.com/api-docs/domains_v2.html#tag/Domains/operation/domains-get
+type Domain struct {
+	DomainName string `json:"domainName"`
+}
+
+// Domains is one page of the paginated domains listing response.
+type Domains struct {
+	Domains  []Domain `json:"domains"`
+	Metadata struct {
+		TotalCount int `json:"totalCount"`
+		Limit      int `json:"limit"`
+		Offset     int `json:"offset"`
+	} `json:"_metadata"`
+}
+
+// NameServer is one of the authoritative nameservers serving a domain.
+type NameServer struct {
+	Name string `json:"name"`
+}
+
+// DomainDetails is the response returned for a single domain, including the
+// nameservers authoritative for it.
+//
+// @see https://developer.leaseweb.com/api-docs/domains_v2.html#tag/Domains/operation/domains-get-1
+type DomainDetails struct {
+	DomainName  string       `json:"domainName"`
+	NameServers []NameServer `json:"nameServers"`
+}
@@ -0,0 +1,193 @@
+package leaseweb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	defaultPropagationTimeout = 2 * time.Minute
+	defaultPollingInterval    = 2 * time.Second
+	defaultNameserverTimeout  = 10 * time.Second
+)
+
+// propagationConfig holds the options configurable via PropagationOption.
+type propagationConfig struct {
+	interval          time.Duration
+	nameserverTimeout time.Duration
+}
+
+// PropagationOption configures a single WaitForPropagation call.
+type PropagationOption func(*propagationConfig)
+
+// WithPollingInterval overrides how often WaitForPropagation re-queries the
+// authoritative nameservers. Defaults to PollingInterval(); a zero or
+// negative interval is treated as unset and also falls back to it.
+func WithPollingInterval(interval time.Duration) PropagationOption {
+	return func(c *propagationConfig) { c.interval = interval }
+}
+
+// WithNameserverTimeout bounds how long a single query to one authoritative
+// nameserver may take. Defaults to 10s.
+func WithNameserverTimeout(timeout time.Duration) PropagationOption {
+	return func(c *propagationConfig) { c.nameserverTimeout = timeout }
+}
+
+// PropagationTimeout returns the upper bound a caller should wait for DNS-01
+// propagation.
+func (p *Provider) PropagationTimeout() time.Duration {
+	if p.WaitForPropagationTimeout > 0 {
+		return p.WaitForPropagationTimeout
+	}
+	return defaultPropagationTimeout
+}
+
+// PollingInterval returns the default interval between propagation checks.
+func (p *Provider) PollingInterval() time.Duration {
+	if p.WaitForPropagationInterval > 0 {
+		return p.WaitForPropagationInterval
+	}
+	return defaultPollingInterval
+}
+
+// Timeout reports PropagationTimeout and PollingInterval as a pair, which is
+// the exact shape lego's challenge.ProviderTimeout interface expects. Note
+// that challenge.Provider also requires Present/CleanUp methods that
+// Provider doesn't implement (it speaks the libdns interfaces instead), so
+// using this with lego still means writing a small adapter around Provider.
+func (p *Provider) Timeout() (timeout, interval time.Duration) {
+	return p.PropagationTimeout(), p.PollingInterval()
+}
+
+// WaitForPropagation polls fqdn's authoritative nameservers (discovered via
+// the domains API) until all of them serve a TXT record containing
+// expectedValue, or ctx expires. It's intended for the _acme-challenge TXT
+// record used by ACME's DNS-01 challenge, but works for any TXT record.
+func (p *Provider) WaitForPropagation(ctx context.Context, fqdn, expectedValue string, opts ...PropagationOption) error {
+	cfg := propagationConfig{
+		interval:          p.PollingInterval(),
+		nameserverTimeout: defaultNameserverTimeout,
+	}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.interval <= 0 {
+		cfg.interval = p.PollingInterval()
+	}
+
+	nameservers, err := p.authoritativeNameservers(ctx, fqdn)
+	if err != nil {
+		return fmt.Errorf("leaseweb: resolving authoritative nameservers for %s: %w", fqdn, err)
+	}
+
+	ticker := time.NewTicker(cfg.interval)
+	defer ticker.Stop()
+
+	for {
+		propagated, err := allNameserversHaveTXT(ctx, nameservers, fqdn, expectedValue, cfg.nameserverTimeout)
+		if err == nil && propagated {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("leaseweb: waiting for %s to propagate: %w", fqdn, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// authoritativeNameservers finds the zone managing fqdn and returns the
+// hostnames of its authoritative nameservers.
+func (p *Provider) authoritativeNameservers(ctx context.Context, fqdn string) ([]string, error) {
+	zone, err := p.zoneForFQDN(ctx, fqdn)
+	if err != nil {
+		return nil, err
+	}
+
+	domain, err := p.getClient().GetDomain(ctx, zone)
+	if err != nil {
+		return nil, err
+	}
+
+	var nameservers []string
+	for _, ns := range domain.NameServers {
+		nameservers = append(nameservers, ns.Name)
+	}
+	if len(nameservers) == 0 {
+		return nil, fmt.Errorf("no nameservers found for zone %s", zone)
+	}
+
+	return nameservers, nil
+}
+
+// zoneForFQDN returns the most specific zone managed by this Provider's API
+// key that fqdn belongs to.
+func (p *Provider) zoneForFQDN(ctx context.Context, fqdn string) (string, error) {
+	zones, err := p.ListZones(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	fqdn = strings.TrimSuffix(fqdn, ".")
+
+	var best string
+	for _, zone := range zones {
+		name := strings.TrimSuffix(zone.Name, ".")
+		if fqdn != name && !strings.HasSuffix(fqdn, "."+name) {
+			continue
+		}
+		if len(name) > len(best) {
+			best = name
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("no managed zone found for %s", fqdn)
+	}
+
+	return best, nil
+}
+
+// allNameserversHaveTXT reports whether every nameserver in nameservers
+// currently answers fqdn with a TXT record containing expectedValue.
+func allNameserversHaveTXT(ctx context.Context, nameservers []string, fqdn, expectedValue string, timeout time.Duration) (bool, error) {
+	for _, nameserver := range nameservers {
+		values, err := lookupTXT(ctx, nameserver, fqdn, timeout)
+		if err != nil {
+			return false, fmt.Errorf("querying %s: %w", nameserver, err)
+		}
+		if !containsString(values, expectedValue) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// lookupTXT queries nameserver directly for fqdn's TXT records, bypassing
+// whatever recursive resolver the host is configured with.
+func lookupTXT(ctx context.Context, nameserver, fqdn string, timeout time.Duration) ([]string, error) {
+	resolver := &net.Resolver{
+		PreferGo: true,
+		Dial: func(ctx context.Context, network, _ string) (net.Conn, error) {
+			var d net.Dialer
+			return d.DialContext(ctx, network, net.JoinHostPort(nameserver, "53"))
+		},
+	}
+
+	lookupCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	return resolver.LookupTXT(lookupCtx, fqdn)
+}
+
+func containsString(values []string, want string) bool {
+	for _, v := range values {
+		if v == want {
+			return true
+		}
+	}
+	return false
+}
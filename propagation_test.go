@@ -0,0 +1,98 @@
+package leaseweb
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProvider_ZoneForFQDN(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"domains":[{"domainName":"example.com"}],"_metadata":{"totalCount":1,"limit":50,"offset":0}}`))
+	})
+
+	tests := []struct {
+		fqdn    string
+		want    string
+		wantErr bool
+	}{
+		{fqdn: "_acme-challenge.example.com.", want: "example.com"},
+		{fqdn: "_acme-challenge.example.com", want: "example.com"},
+		{fqdn: "example.com.", want: "example.com"},
+		{fqdn: "_acme-challenge.other.com.", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.fqdn, func(t *testing.T) {
+			got, err := p.zoneForFQDN(context.Background(), tt.fqdn)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("zoneForFQDN() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("zoneForFQDN() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProvider_PropagationTimeoutAndPollingInterval(t *testing.T) {
+	p := &Provider{}
+	if got := p.PropagationTimeout(); got != defaultPropagationTimeout {
+		t.Errorf("PropagationTimeout() = %v, want %v", got, defaultPropagationTimeout)
+	}
+	if got := p.PollingInterval(); got != defaultPollingInterval {
+		t.Errorf("PollingInterval() = %v, want %v", got, defaultPollingInterval)
+	}
+
+	p.WaitForPropagationTimeout = 5 * time.Minute
+	p.WaitForPropagationInterval = time.Second
+	if got := p.PropagationTimeout(); got != 5*time.Minute {
+		t.Errorf("PropagationTimeout() = %v, want %v", got, 5*time.Minute)
+	}
+	if got := p.PollingInterval(); got != time.Second {
+		t.Errorf("PollingInterval() = %v, want %v", got, time.Second)
+	}
+
+	gotTimeout, gotInterval := p.Timeout()
+	if gotTimeout != p.PropagationTimeout() || gotInterval != p.PollingInterval() {
+		t.Errorf("Timeout() = (%v, %v), want (%v, %v)", gotTimeout, gotInterval, p.PropagationTimeout(), p.PollingInterval())
+	}
+}
+
+func TestProvider_WaitForPropagation_ZeroInterval(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/domains/"):
+			// A TEST-NET-2 address (RFC 5737): routable enough for the UDP
+			// dial to succeed, guaranteed not to answer, so lookupTXT blocks
+			// until its own timeout instead of resolving a real nameserver.
+			w.Write([]byte(`{"domainName":"example.com","nameServers":[{"name":"198.51.100.1"}]}`))
+		default:
+			w.Write([]byte(`{"domains":[{"domainName":"example.com"}],"_metadata":{"totalCount":1,"limit":50,"offset":0}}`))
+		}
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	// WithPollingInterval(0) must not panic with "non-positive interval for
+	// NewTicker"; it should silently fall back to p.PollingInterval() like
+	// the doc comment promises.
+	err := p.WaitForPropagation(ctx, "_acme-challenge.example.com.", "token",
+		WithPollingInterval(0), WithNameserverTimeout(20*time.Millisecond))
+	if err == nil {
+		t.Fatal("expected an error once ctx expires, got nil")
+	}
+}
+
+func TestContainsString(t *testing.T) {
+	values := []string{"token-one", "token-two"}
+	if !containsString(values, "token-two") {
+		t.Error("containsString() = false, want true")
+	}
+	if containsString(values, "token-three") {
+		t.Error("containsString() = true, want false")
+	}
+}
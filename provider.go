@@ -5,137 +5,91 @@
 package leaseweb
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
 	"fmt"
-	"io/ioutil"
 	"net/http"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/libdns/leaseweb/internal/client"
 	"github.com/libdns/libdns"
 )
 
-const (
-	LeasewebApiKeyHeader = "X-LSW-Auth"
-)
+// LeasewebApiKeyHeader is kept for backwards compatibility with callers that
+// referenced it directly; the client itself sets this header internally.
+const LeasewebApiKeyHeader = client.APIKeyHeader
 
 // Provider facilitates DNS record manipulation with Leaseweb.
 type Provider struct {
 	// Leasewebs API key. Generate one in the Leaseweb customer portal -> Administration -> API Key
 	APIKey string `json:"api_token,omitempty"`
-	mutex  sync.Mutex
-}
-
-func fromLibdns(zone string, records []libdns.Record) ([]leasewebRecordSet, error) {
-	var recordsInfo = []struct {
-		libdnsRecord libdns.Record
-		consumed     bool
-	}{}
-	for _, record := range records {
-		recordsInfo = append(recordsInfo, struct {
-			libdnsRecord libdns.Record
-			consumed     bool
-		}{
-			libdnsRecord: record,
-			consumed:     false,
-		})
-	}
-
-	var errors []string
-	var recordSets []leasewebRecordSet
-
-	for currentIdx := 0; currentIdx < len(recordsInfo); currentIdx++ {
-		var currentRecordInfo = &recordsInfo[currentIdx]
-
-		if currentRecordInfo.consumed {
-			continue
-		}
-		currentRecordInfo.consumed = true
-
-		var newRecordSet = leasewebRecordSet{
-			Name:    currentRecordInfo.libdnsRecord.Name,
-			Type:    currentRecordInfo.libdnsRecord.Type,
-			TTL:     int(currentRecordInfo.libdnsRecord.TTL.Seconds()),
-			Content: []string{currentRecordInfo.libdnsRecord.Value},
-		}
-
-		for otherIdx := 0; otherIdx < len(recordsInfo); otherIdx++ {
-			var otherRecordInfo = &recordsInfo[otherIdx]
-			if otherIdx == currentIdx {
-				continue
-			}
 
-			if otherRecordInfo.libdnsRecord.Name == newRecordSet.Name && otherRecordInfo.libdnsRecord.Type == currentRecordInfo.libdnsRecord.Type {
-				otherRecordInfo.consumed = true
-
-				var otherTTL = int(otherRecordInfo.libdnsRecord.TTL.Seconds())
-				if otherTTL != newRecordSet.TTL {
-					errors = append(errors, fmt.Sprintf("Found different TTL values for %s: %d and %d.", newRecordSet.Name, newRecordSet.TTL, otherTTL))
-				}
-
-				newRecordSet.Content = append(newRecordSet.Content, otherRecordInfo.libdnsRecord.Value)
-			}
-		}
-		recordSets = append(recordSets, newRecordSet)
-	}
-
-	if len(errors) > 0 {
-		return nil, fmt.Errorf("%v", errors)
-	}
-
-	return recordSets, nil
+	// HTTPClient optionally overrides the *http.Client used to talk to the
+	// Leaseweb API. Mainly useful for tests that need custom transports.
+	HTTPClient *http.Client `json:"-"`
+	// MaxRetries overrides how many times a request is retried on 429/5xx
+	// responses. Defaults to 3 when left zero; set to a negative value to
+	// disable retries entirely.
+	MaxRetries int `json:"-"`
+	// BaseURL overrides the Leaseweb API base URL. Mainly useful for
+	// pointing tests at an httptest.Server.
+	BaseURL string `json:"-"`
+
+	// WaitForPropagationTimeout overrides the duration returned by
+	// PropagationTimeout. Defaults to 2 minutes when left zero.
+	WaitForPropagationTimeout time.Duration `json:"-"`
+	// WaitForPropagationInterval overrides the duration returned by
+	// PollingInterval. Defaults to 2 seconds when left zero.
+	WaitForPropagationInterval time.Duration `json:"-"`
+
+	client     *client.Client
+	clientOnce sync.Once
+	mutex      sync.Mutex
 }
 
-func fromLeaseweb(recordSets leasewebRecordSets) []libdns.Record {
-	var records []libdns.Record
-	for _, resourceRecordSet := range recordSets.ResourceRecordSets {
-		for _, content := range resourceRecordSet.Content {
-			record := libdns.Record{
-				Name:  resourceRecordSet.Name,
-				Value: content,
-				Type:  resourceRecordSet.Type,
-				TTL:   time.Duration(resourceRecordSet.TTL) * time.Second,
-			}
-			records = append(records, record)
+// getClient lazily builds the one *client.Client this Provider reuses for
+// every request, so the underlying *http.Client (and its connection pool)
+// is shared rather than recreated per call.
+func (p *Provider) getClient() *client.Client {
+	p.clientOnce.Do(func() {
+		p.client = &client.Client{
+			HTTPClient: p.HTTPClient,
+			BaseURL:    p.BaseURL,
+			APIKey:     p.APIKey,
+			MaxRetries: p.MaxRetries,
 		}
-	}
-	return records
+	})
+	return p.client
 }
 
-func (p *Provider) getRecordsHTTP(domainName string) (leasewebRecordSets, error) {
-	httpClient := &http.Client{}
+// listZonesPageSize is how many domains are requested per page when
+// paginating the domains listing.
+const listZonesPageSize = 50
 
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://api.leaseweb.com/hosting/v2/domains/%s/resourceRecordSets", domainName), nil)
-	if err != nil {
-		return leasewebRecordSets{}, err
-	}
+// ListZones lists the zones (domains) manageable with this Provider's API
+// key, so callers don't need to hardcode a single zone.
+func (p *Provider) ListZones(ctx context.Context) ([]libdns.Zone, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
 
-	req.Header.Add(LeasewebApiKeyHeader, p.APIKey)
+	var zones []libdns.Zone
+	for offset := 0; ; offset += listZonesPageSize {
+		page, err := p.getClient().ListDomains(ctx, listZonesPageSize, offset)
+		if err != nil {
+			return nil, err
+		}
 
-	res, err := httpClient.Do(req)
-	defer res.Body.Close()
-	if err != nil {
-		return leasewebRecordSets{}, err
-	}
-	// if res.StatusCode == 401 {
-	// 	return nil, fmt.Errorf("Received StatusCode %d from Leaseweb API, used APIKey: %s", res.StatusCode, p.APIKey)
-	// }
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return leasewebRecordSets{}, fmt.Errorf("Received StatusCode %d from Leaseweb API.", res.StatusCode)
-	}
+		for _, domain := range page.Domains {
+			zones = append(zones, libdns.Zone{Name: domain.DomainName})
+		}
 
-	data, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return leasewebRecordSets{}, err
+		if offset+len(page.Domains) >= page.Metadata.TotalCount || len(page.Domains) == 0 {
+			break
+		}
 	}
 
-	var recordSets leasewebRecordSets
-	json.Unmarshal([]byte(data), &recordSets)
-
-	return recordSets, nil
+	return zones, nil
 }
 
 // GetRecords lists all the records in the zone.
@@ -145,77 +99,12 @@ func (p *Provider) GetRecords(ctx context.Context, zone string) ([]libdns.Record
 
 	domainName := strings.TrimSuffix(zone, ".")
 
-	recordSets, err := p.getRecordsHTTP(domainName)
+	recordSets, err := p.getClient().GetRecordSets(ctx, domainName)
 	if err != nil {
 		return nil, err
 	}
 
-	records := fromLeaseweb(recordSets)
-
-	return records, nil
-}
-
-func (p *Provider) postToResourceRecordSet(zone string, recordSet leasewebRecordSet) (leasewebRecordSet, error) {
-	client := &http.Client{}
-
-	bodyBuffer := new(bytes.Buffer)
-	json.NewEncoder(bodyBuffer).Encode(recordSet)
-
-	var domainName = strings.TrimSuffix(zone, ".")
-
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("https://api.leaseweb.com/hosting/v2/domains/%s/resourceRecordSets", domainName), bodyBuffer)
-	if err != nil {
-		return leasewebRecordSet{}, err
-	}
-
-	req.Header.Add(LeasewebApiKeyHeader, p.APIKey)
-
-	res, err := client.Do(req)
-	defer res.Body.Close()
-	if err != nil {
-		return leasewebRecordSet{}, err
-	}
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-		return leasewebRecordSet{}, fmt.Errorf("Received StatusCode %d from Leaseweb API.", res.StatusCode)
-	}
-
-	data, err := ioutil.ReadAll(res.Body)
-	if err != nil {
-		return leasewebRecordSet{}, err
-	}
-
-	json.Unmarshal([]byte(data), &recordSet)
-	return recordSet, nil
-}
-
-func (p *Provider) putToResourceRecordSet(domainName string, recordSet leasewebRecordSet) (leasewebRecordSets, error) {
-	client := &http.Client{}
-
-	bodyBuffer := new(bytes.Buffer)
-	json.NewEncoder(bodyBuffer).Encode(&updateRecordSetRequest{
-		Content: recordSet.Content,
-		TTL:     recordSet.TTL,
-	})
-
-	// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/put/domains/{domainName}/resourceRecordSets/{name}/{type}
-	// https://api.leaseweb.com/hosting/v2/domains/{domainName}/resourceRecordSets/{name}/{type}
-	req, err := http.NewRequest(http.MethodPut, fmt.Sprintf("https://api.leaseweb.com/hosting/v2/domains/%s/resourceRecordSets/%s/%s", domainName, recordSet.Name, recordSet.Type), bodyBuffer)
-	if err != nil {
-		return leasewebRecordSets{}, err
-	}
-	req.Header.Add(LeasewebApiKeyHeader, p.APIKey)
-
-	res, err := client.Do(req)
-	defer res.Body.Close()
-	if err != nil {
-		return leasewebRecordSets{}, err
-	}
-	if res.StatusCode < 200 || res.StatusCode > 299 {
-
-		return leasewebRecordSets{}, fmt.Errorf("Received StatusCode %d from Leaseweb API. %s", res.StatusCode, res.Body)
-	}
-
-	return leasewebRecordSets{}, nil
+	return fromLeaseweb(recordSets), nil
 }
 
 // AppendRecords adds records to the zone. It returns the records that were added.
@@ -223,14 +112,15 @@ func (p *Provider) AppendRecords(ctx context.Context, zone string, records []lib
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
+	domainName := strings.TrimSuffix(zone, ".")
+
 	recordSets, err := fromLibdns(zone, records)
 	if err != nil {
 		return nil, err
 	}
 
 	for _, recordSet := range recordSets {
-		_, err := p.postToResourceRecordSet(zone, recordSet)
-		if err != nil {
+		if _, err := p.getClient().CreateRecordSet(ctx, domainName, recordSet); err != nil {
 			return nil, err
 		}
 	}
@@ -248,7 +138,7 @@ func (p *Provider) SetRecords(ctx context.Context, zone string, records []libdns
 	defer p.mutex.Unlock()
 
 	domainName := strings.TrimSuffix(zone, ".")
-	existingRecordSets, err := p.getRecordsHTTP(domainName)
+	existingRecordSets, err := p.getClient().GetRecordSets(ctx, domainName)
 	if err != nil {
 		return nil, err
 	}
@@ -270,25 +160,43 @@ func (p *Provider) SetRecords(ctx context.Context, zone string, records []libdns
 		}
 
 		if hasExisting {
-			updatedRecordResponse, err := p.putToResourceRecordSet(zone, recordSet)
-			if err != nil {
-				return nil, err
+			if err := p.getClient().UpdateRecordSet(ctx, domainName, recordSet); err != nil {
+				return nil, &SetRecordsError{Err: err, Successful: updatedRecords}
 			}
 
-			for _, updatedRecord := range fromLeaseweb(updatedRecordResponse) {
-				updatedRecords = append(updatedRecords, updatedRecord)
-			}
+			// The PUT endpoint doesn't return a body, so the content/TTL we
+			// just sent is also the server-confirmed value.
+			updatedRecords = append(updatedRecords, fromLeaseweb(client.RecordSets{ResourceRecordSets: []client.RecordSet{recordSet}})...)
 		} else {
-			_, err := p.postToResourceRecordSet(zone, recordSet)
+			created, err := p.getClient().CreateRecordSet(ctx, domainName, recordSet)
 			if err != nil {
-				return nil, err
+				return nil, &SetRecordsError{Err: err, Successful: updatedRecords}
 			}
+
+			updatedRecords = append(updatedRecords, fromLeaseweb(client.RecordSets{ResourceRecordSets: []client.RecordSet{created}})...)
 		}
 	}
 
 	return updatedRecords, nil
 }
 
+// SetRecordsError is returned by SetRecords when one of several record sets
+// fails to be created or updated. Leaseweb has no batch/transactional API,
+// so earlier successes in the same call are not rolled back; Successful
+// holds the records that were applied before Err occurred.
+type SetRecordsError struct {
+	Err        error
+	Successful []libdns.Record
+}
+
+func (e *SetRecordsError) Error() string {
+	return fmt.Sprintf("leaseweb: set records: %v (%d record(s) applied before the error)", e.Err, len(e.Successful))
+}
+
+func (e *SetRecordsError) Unwrap() error {
+	return e.Err
+}
+
 // DeleteRecords deletes the records from the zone. It returns the records that were deleted.
 // Leaseweb specifics:
 // - Well-formatted DELETE requests will always succeed, even for non-existing records.
@@ -296,33 +204,17 @@ func (p *Provider) DeleteRecords(ctx context.Context, zone string, records []lib
 	p.mutex.Lock()
 	defer p.mutex.Unlock()
 
-	client := &http.Client{}
-
-	var domainName = strings.TrimSuffix(zone, ".")
+	domainName := strings.TrimSuffix(zone, ".")
 
 	recordSets, err := fromLibdns(zone, records)
+	if err != nil {
+		return nil, err
+	}
 
 	for _, recordSet := range recordSets {
-		if err != nil {
-			return nil, err
-		}
-
-		// https://developer.leaseweb.com/api-docs/domains_v2.html#operation/delete/domains/{domainName}/resourceRecordSets/{name}/{type}
-		req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("https://api.leaseweb.com/hosting/v2/domains/%s/resourceRecordSets/%s/%s", domainName, recordSet.Name, recordSet.Type), nil)
-		if err != nil {
+		if err := p.getClient().DeleteRecordSet(ctx, domainName, recordSet.Name, recordSet.Type); err != nil {
 			return nil, err
 		}
-
-		req.Header.Add(LeasewebApiKeyHeader, p.APIKey)
-
-		res, err := client.Do(req)
-		defer res.Body.Close()
-		if err != nil {
-			return nil, err
-		}
-		if res.StatusCode < 200 || res.StatusCode > 299 {
-			return nil, fmt.Errorf("Received StatusCode %d from Leaseweb API.", res.StatusCode)
-		}
 	}
 
 	// TODO: Ideally should check which records are actually POSTed.
@@ -337,4 +229,5 @@ var (
 	_ libdns.RecordAppender = (*Provider)(nil)
 	_ libdns.RecordSetter   = (*Provider)(nil)
 	_ libdns.RecordDeleter  = (*Provider)(nil)
+	_ libdns.ZoneLister     = (*Provider)(nil)
 )
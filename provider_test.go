@@ -0,0 +1,230 @@
+package leaseweb
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/libdns/libdns"
+)
+
+func newTestProvider(t *testing.T, handler http.HandlerFunc) *Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	return &Provider{
+		APIKey:     "test-api-key",
+		HTTPClient: server.Client(),
+		BaseURL:    server.URL,
+	}
+}
+
+func mustReadFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile("internal/client/testdata/" + name)
+	if err != nil {
+		t.Fatalf("reading fixture %s: %v", name, err)
+	}
+	return data
+}
+
+func TestProvider_ListZones(t *testing.T) {
+	// ListZones always pages with listZonesPageSize (50), unlike
+	// TestClient_ListDomains' limit=2 fixtures, so the offsets below must
+	// match that to actually exercise ListZones' pagination arithmetic.
+	page1 := []byte(`{"domains":[{"domainName":"example.com"},{"domainName":"example.net"}],"_metadata":{"totalCount":3,"limit":50,"offset":0}}`)
+	page2 := []byte(`{"domains":[{"domainName":"example.org"}],"_metadata":{"totalCount":3,"limit":50,"offset":50}}`)
+
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Query().Get("offset") {
+		case "0":
+			w.Write(page1)
+		case "50":
+			w.Write(page2)
+		default:
+			t.Errorf("unexpected offset query: %s", r.URL.RawQuery)
+		}
+	})
+
+	zones, err := p.ListZones(context.Background())
+	if err != nil {
+		t.Fatalf("ListZones() error = %v", err)
+	}
+
+	want := []string{"example.com", "example.net", "example.org"}
+	if len(zones) != len(want) {
+		t.Fatalf("got %d zones, want %d", len(zones), len(want))
+	}
+	for i, zone := range zones {
+		if zone.Name != want[i] {
+			t.Errorf("zones[%d].Name = %q, want %q", i, zone.Name, want[i])
+		}
+	}
+}
+
+func TestProvider_GetRecords(t *testing.T) {
+	fixture := mustReadFixture(t, "list_record_sets.json")
+
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write(fixture)
+	})
+
+	records, err := p.GetRecords(context.Background(), "example.com.")
+	if err != nil {
+		t.Fatalf("GetRecords() error = %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want 3", len(records))
+	}
+}
+
+func TestProvider_AppendRecords(t *testing.T) {
+	var gotMethod string
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"name":"www.example.com.","type":"A","content":["203.0.113.10"],"ttl":3600}`))
+	})
+
+	records, err := p.AppendRecords(context.Background(), "example.com.", []libdns.Record{
+		{Name: "www", Type: "A", Value: "203.0.113.10", TTL: 3600 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("AppendRecords() error = %v", err)
+	}
+	if gotMethod != http.MethodPost {
+		t.Errorf("method = %q, want POST", gotMethod)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+}
+
+func TestProvider_SetRecords_UpdatesExisting(t *testing.T) {
+	var gotMethods []string
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethods = append(gotMethods, r.Method)
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"resourceRecordSets":[{"name":"www.example.com.","type":"A","content":["203.0.113.10"],"ttl":3600}]}`))
+		case http.MethodPut:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	records, err := p.SetRecords(context.Background(), "example.com.", []libdns.Record{
+		{Name: "www", Type: "A", Value: "203.0.113.20", TTL: 3600 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("SetRecords() error = %v", err)
+	}
+	if want := []string{http.MethodGet, http.MethodPut}; !equalStrings(gotMethods, want) {
+		t.Errorf("methods = %v, want %v", gotMethods, want)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+}
+
+func TestProvider_SetRecords_CreatesMissing(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"resourceRecordSets":[]}`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name":"www.example.com.","type":"A","content":["203.0.113.20"],"ttl":3600}`))
+		}
+	})
+
+	records, err := p.SetRecords(context.Background(), "example.com.", []libdns.Record{
+		{Name: "www", Type: "A", Value: "203.0.113.20", TTL: 3600 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("SetRecords() error = %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+}
+
+func TestProvider_SetRecords_PartialFailureReportsSuccessful(t *testing.T) {
+	var calls int
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"resourceRecordSets":[]}`))
+			return
+		}
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"name":"www.example.com.","type":"A","content":["203.0.113.20"],"ttl":3600}`))
+			return
+		}
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"userMessage":"boom"}`))
+	})
+	p.MaxRetries = 1
+
+	_, err := p.SetRecords(context.Background(), "example.com.", []libdns.Record{
+		{Name: "www", Type: "A", Value: "203.0.113.20", TTL: 3600 * time.Second},
+		{Name: "api", Type: "A", Value: "203.0.113.21", TTL: 3600 * time.Second},
+	})
+
+	var setErr *SetRecordsError
+	if !errors.As(err, &setErr) {
+		t.Fatalf("error = %v, want *SetRecordsError", err)
+	}
+	if len(setErr.Successful) != 1 {
+		t.Fatalf("got %d successful records, want 1", len(setErr.Successful))
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestProvider_DeleteRecords(t *testing.T) {
+	var gotMethod string
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		gotMethod = r.Method
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	records, err := p.DeleteRecords(context.Background(), "example.com.", []libdns.Record{
+		{Name: "www", Type: "A", Value: "203.0.113.10", TTL: 3600 * time.Second},
+	})
+	if err != nil {
+		t.Fatalf("DeleteRecords() error = %v", err)
+	}
+	if gotMethod != http.MethodDelete {
+		t.Errorf("method = %q, want DELETE", gotMethod)
+	}
+	if len(records) != 1 {
+		t.Fatalf("got %d records, want 1", len(records))
+	}
+}
+
+func TestProvider_GetRecords_Error(t *testing.T) {
+	p := newTestProvider(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"userMessage":"invalid credentials"}`))
+	})
+
+	if _, err := p.GetRecords(context.Background(), "example.com."); err == nil {
+		t.Fatal("expected an error, got nil")
+	}
+}